@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/git-town/git-town/src/cli"
+	"github.com/git-town/git-town/src/git"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheCommand = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages Git Town's on-disk cache",
+}
+
+var cacheClearCommand = &cobra.Command{
+	Use:   "clear",
+	Short: "Deletes Git Town's on-disk cache",
+	Long: `Deletes Git Town's on-disk cache
+
+Removes the cached branch lists, parent-branch lookups, and remote
+enumeration results stored under ".git/town-cache". The next command
+recomputes and repopulates the cache from scratch.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitDir, err := prodRepo.Silent.GitDir()
+		if err != nil {
+			cli.Exit(err)
+		}
+		err = git.ClearPersistentCaches(gitDir)
+		if err != nil {
+			cli.Exit(err)
+		}
+	},
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return ValidateIsRepository(prodRepo)
+	},
+}
+
+func init() {
+	cacheCommand.AddCommand(cacheClearCommand)
+	RootCmd.AddCommand(cacheCommand)
+}
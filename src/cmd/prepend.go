@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/git-town/git-town/src/cli"
 	"github.com/git-town/git-town/src/git"
@@ -14,15 +15,17 @@ import (
 type prependConfig struct {
 	initialBranch       string
 	parentBranch        string
-	targetBranch        string
+	targetBranches      []string
 	ancestorBranches    []string
 	hasOrigin           bool
 	shouldNewBranchPush bool
 	isOffline           bool
 }
 
+var prependPushFlag bool
+
 var prependCommand = &cobra.Command{
-	Use:   "prepend <branch>",
+	Use:   "prepend <branch> [<branch>]...",
 	Short: "Creates a new feature branch as the parent of the current branch",
 	Long: `Creates a new feature branch as the parent of the current branch
 
@@ -30,9 +33,14 @@ Syncs the parent branch,
 cuts a new feature branch with the given name off the parent branch,
 makes the new branch the parent of the current branch,
 pushes the new feature branch to the remote repository
-(if "new-branch-push-flag" is true),
+(if "new-branch-push-flag" is true, or "--push" was given),
 and brings over all uncommitted changes to the new feature branch.
 
+When given multiple branch names, creates a linear chain of new feature
+branches between the current branch and its parent, in the order given,
+and checks out the first one. This supports building a stack of feature
+branches below a branch that already has work in progress.
+
 See "sync" for remote upstream options.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -51,7 +59,7 @@ See "sync" for remote upstream options.
 			cli.Exit(err)
 		}
 	},
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MinimumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if err := ValidateIsRepository(prodRepo); err != nil {
 			return err
@@ -65,12 +73,17 @@ func getPrependConfig(args []string, repo *git.ProdRepo) (result prependConfig,
 	if err != nil {
 		return result, err
 	}
-	result.targetBranch = args[0]
-	result.hasOrigin, err = repo.Silent.HasRemote("origin")
+	result.targetBranches = args
+	gitDir, err := repo.Silent.GitDir()
+	if err != nil {
+		return result, err
+	}
+	remotes, err := git.CachedRemotes(gitDir, repo.Silent.Remotes)
 	if err != nil {
 		return result, err
 	}
-	result.shouldNewBranchPush = repo.Config.ShouldNewBranchPush()
+	result.hasOrigin = containsString(remotes, "origin")
+	result.shouldNewBranchPush = repo.Config.ShouldNewBranchPush() || prependPushFlag
 	result.isOffline = repo.Config.IsOffline()
 	if result.hasOrigin && !result.isOffline {
 		err := repo.Logging.Fetch()
@@ -78,12 +91,12 @@ func getPrependConfig(args []string, repo *git.ProdRepo) (result prependConfig,
 			return result, err
 		}
 	}
-	hasBranch, err := repo.Silent.HasLocalOrRemoteBranch(result.targetBranch)
+	existingBranches, err := findExistingBranches(gitDir, result.targetBranches, repo)
 	if err != nil {
 		return result, err
 	}
-	if hasBranch {
-		return result, fmt.Errorf("a branch named %q already exists", result.targetBranch)
+	if len(existingBranches) > 0 {
+		return result, fmt.Errorf("cannot prepend: the following branches already exist: %s", strings.Join(existingBranches, ", "))
 	}
 	if !repo.Config.IsFeatureBranch(result.initialBranch) {
 		return result, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can have parent branches", result.initialBranch)
@@ -92,11 +105,42 @@ func getPrependConfig(args []string, repo *git.ProdRepo) (result prependConfig,
 	if err != nil {
 		return result, err
 	}
-	result.parentBranch = repo.Config.GetParentBranch(result.initialBranch)
+	result.parentBranch, err = git.CachedParentBranch(gitDir, result.initialBranch, func() (string, error) {
+		return repo.Config.GetParentBranch(result.initialBranch), nil
+	})
+	if err != nil {
+		return result, err
+	}
 	result.ancestorBranches = repo.Config.GetAncestorBranches(result.initialBranch)
 	return result, nil
 }
 
+// findExistingBranches returns the subset of the given branch names that
+// already exist, locally or remotely, so that "prepend" can reject the
+// whole operation up front instead of failing halfway through the chain.
+func findExistingBranches(gitDir string, branchNames []string, repo *git.ProdRepo) (result []string, err error) {
+	allBranches, err := git.CachedLocalBranches(gitDir, repo.Silent.LocalAndRemoteBranches)
+	if err != nil {
+		return result, err
+	}
+	for _, branchName := range branchNames {
+		if containsString(allBranches, branchName) {
+			result = append(result, branchName)
+		}
+	}
+	return result, nil
+}
+
+// containsString reports whether values contains target.
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
 func getPrependStepList(config prependConfig, repo *git.ProdRepo) (result steps.StepList, err error) {
 	for _, branchName := range config.ancestorBranches {
 		steps, err := steps.GetSyncBranchSteps(branchName, true, repo)
@@ -105,17 +149,22 @@ func getPrependStepList(config prependConfig, repo *git.ProdRepo) (result steps.
 		}
 		result.AppendList(steps)
 	}
-	result.Append(&steps.CreateBranchStep{BranchName: config.targetBranch, StartingPoint: config.parentBranch})
-	result.Append(&steps.SetParentBranchStep{BranchName: config.targetBranch, ParentBranchName: config.parentBranch})
-	result.Append(&steps.SetParentBranchStep{BranchName: config.initialBranch, ParentBranchName: config.targetBranch})
-	result.Append(&steps.CheckoutBranchStep{BranchName: config.targetBranch})
-	if config.hasOrigin && config.shouldNewBranchPush && !config.isOffline {
-		result.Append(&steps.CreateTrackingBranchStep{BranchName: config.targetBranch})
+	startingPoint := config.parentBranch
+	for _, targetBranch := range config.targetBranches {
+		result.Append(&steps.CreateBranchStep{BranchName: targetBranch, StartingPoint: startingPoint})
+		result.Append(&steps.SetParentBranchStep{BranchName: targetBranch, ParentBranchName: startingPoint})
+		if config.hasOrigin && config.shouldNewBranchPush && !config.isOffline {
+			result.Append(&steps.CreateTrackingBranchStep{BranchName: targetBranch})
+		}
+		startingPoint = targetBranch
 	}
+	result.Append(&steps.SetParentBranchStep{BranchName: config.initialBranch, ParentBranchName: startingPoint})
+	result.Append(&steps.CheckoutBranchStep{BranchName: config.targetBranches[0]})
 	err = result.Wrap(steps.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, repo)
 	return result, err
 }
 
 func init() {
+	prependCommand.Flags().BoolVar(&prependPushFlag, "push", false, "push the newly created branches to the remote repository for this invocation, regardless of new-branch-push-flag")
 	RootCmd.AddCommand(prependCommand)
 }
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/src/cli"
+	"github.com/git-town/git-town/src/drivers"
+	"github.com/git-town/git-town/src/git"
+	"github.com/git-town/git-town/src/steps"
+
+	"github.com/spf13/cobra"
+)
+
+type shipConfig struct {
+	branchToShip         string
+	initialBranch        string
+	targetBranch         string
+	canShipViaAPI        bool
+	defaultCommitMessage string
+	mergeStrategy        string
+	driver               drivers.CodeHostingDriver
+}
+
+var shipMergeStrategyFlag string
+
+var shipCommand = &cobra.Command{
+	Use:   "ship <branch>",
+	Short: "Deliver a completed feature branch",
+	Long: `Deliver a completed feature branch
+
+Squash-merges the given branch (default: the current branch)
+into its parent branch,
+resulting in a linear history,
+and removes the given branch afterwards.
+
+If a code hosting driver is configured and the branch has an open pull
+request, ships it through that provider's API instead, using the configured
+merge strategy (see "git-town.pull-request-merge-strategy"), which the
+"--merge-strategy" flag overrides for this invocation only.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := getShipConfig(args, prodRepo)
+		if err != nil {
+			cli.Exit(err)
+		}
+		stepList, err := getShipStepList(config, prodRepo)
+		if err != nil {
+			cli.Exit(err)
+		}
+		runState := steps.NewRunState("ship", stepList)
+		err = steps.Run(runState, prodRepo, nil)
+		if err != nil {
+			fmt.Println(err)
+			cli.Exit(err)
+		}
+	},
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := ValidateIsRepository(prodRepo); err != nil {
+			return err
+		}
+		return validateIsConfigured(prodRepo)
+	},
+}
+
+func getShipConfig(args []string, repo *git.ProdRepo) (result shipConfig, err error) {
+	result.initialBranch, err = repo.Silent.CurrentBranch()
+	if err != nil {
+		return result, err
+	}
+	if len(args) > 0 {
+		result.branchToShip = args[0]
+	} else {
+		result.branchToShip = result.initialBranch
+	}
+	if !repo.Config.IsFeatureBranch(result.branchToShip) {
+		return result, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can be shipped", result.branchToShip)
+	}
+	gitDir, err := repo.Silent.GitDir()
+	if err != nil {
+		return result, err
+	}
+	result.targetBranch, err = git.CachedParentBranch(gitDir, result.branchToShip, func() (string, error) {
+		return repo.Config.GetParentBranch(result.branchToShip), nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.mergeStrategy = shipMergeStrategyFlag
+	if result.mergeStrategy == "" {
+		result.mergeStrategy = repo.Config.GetPullRequestMergeStrategy()
+	}
+
+	result.driver, err = drivers.Load(repo.Config, cli.PrintingLogger{})
+	if err != nil {
+		return result, err
+	}
+	if result.driver != nil {
+		prInfo, err := result.driver.LoadPullRequestInfo(result.branchToShip, result.targetBranch)
+		if err != nil {
+			return result, err
+		}
+		result.canShipViaAPI = prInfo.CanMergeWithAPI
+		result.defaultCommitMessage = prInfo.DefaultCommitMessage
+	}
+	return result, nil
+}
+
+func getShipStepList(config shipConfig, repo *git.ProdRepo) (result steps.StepList, err error) {
+	syncSteps, err := steps.GetSyncBranchSteps(config.branchToShip, false, repo)
+	if err != nil {
+		return result, err
+	}
+	result.AppendList(syncSteps)
+	if config.canShipViaAPI {
+		result.Append(&steps.PushBranchStep{BranchName: config.branchToShip})
+		result.Append(&steps.EnsureHasShippableChangesStep{BranchName: config.branchToShip})
+		result.Append(&steps.DriverMergePullRequestStep{
+			BranchName:       config.branchToShip,
+			ParentBranchName: config.targetBranch,
+			CommitMessage:    config.defaultCommitMessage,
+			MergeStrategy:    config.mergeStrategy,
+			Driver:           config.driver,
+		})
+	} else {
+		result.Append(&steps.EnsureHasShippableChangesStep{BranchName: config.branchToShip})
+		result.Append(&steps.SquashMergeBranchStep{BranchName: config.branchToShip, CommitMessage: config.defaultCommitMessage})
+	}
+	result.Append(&steps.DeleteLocalBranchStep{BranchName: config.branchToShip})
+	err = result.Wrap(steps.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, repo)
+	return result, err
+}
+
+func init() {
+	shipCommand.Flags().StringVar(&shipMergeStrategyFlag, "merge-strategy", "", "override the configured pull request merge strategy for this invocation (squash, merge, rebase, fast-forward)")
+	RootCmd.AddCommand(shipCommand)
+}
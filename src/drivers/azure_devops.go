@@ -0,0 +1,194 @@
+package drivers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+type azureDevOpsDriver struct {
+	organization string
+	project      string
+	repo         string
+	token        string
+	log          Log
+}
+
+var azureDevOpsURLRegex = regexp.MustCompile(`dev\.azure\.com[:/]([^/]+)/([^/]+)/_git/([^/]+?)(?:\.git)?$`)
+var azureDevOpsVisualStudioURLRegex = regexp.MustCompile(`([^./@]+)\.visualstudio\.com[:/](?:DefaultCollection/)?([^/]+)/_git/([^/]+?)(?:\.git)?$`)
+
+// LoadAzureDevOps detects whether the current repository is hosted on Azure DevOps
+// and if so, returns a driver that uses the Azure DevOps REST API for its remote operations.
+func LoadAzureDevOps(config Config, log Log) CodeHostingDriver {
+	if config.GetCodeHostingDriverName() != "" && config.GetCodeHostingDriverName() != "azure-devops" {
+		return nil
+	}
+	organization, project, repo := parseAzureDevOpsURL(config.GetRemoteOriginURL())
+	if organization == "" || repo == "" {
+		return nil
+	}
+	return &azureDevOpsDriver{
+		organization: organization,
+		project:      project,
+		repo:         repo,
+		token:        config.GetAzureDevOpsToken(),
+		log:          log,
+	}
+}
+
+func init() {
+	Register("azure-devops", func(config Config) bool {
+		organization, _, _ := parseAzureDevOpsURL(config.GetRemoteOriginURL())
+		return organization != ""
+	}, LoadAzureDevOps)
+}
+
+func parseAzureDevOpsURL(originURL string) (organization, project, repo string) {
+	matches := azureDevOpsURLRegex.FindStringSubmatch(originURL)
+	if matches != nil {
+		return matches[1], matches[2], matches[3]
+	}
+	matches = azureDevOpsVisualStudioURLRegex.FindStringSubmatch(originURL)
+	if matches != nil {
+		return matches[1], matches[2], matches[3]
+	}
+	return "", "", ""
+}
+
+func (d *azureDevOpsDriver) HostingServiceName() string {
+	return "Azure DevOps"
+}
+
+func (d *azureDevOpsDriver) RepositoryURL() string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_git/%s", d.organization, d.project, d.repo)
+}
+
+func (d *azureDevOpsDriver) apiBaseURL() string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s", d.organization, d.project, d.repo)
+}
+
+type azureDevOpsPullRequest struct {
+	PullRequestID     int64  `json:"pullRequestId"`
+	Title             string `json:"title"`
+	TargetRefName     string `json:"targetRefName"`
+	LastMergeSourceCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+	// LastMergeCommit is the commit that Azure DevOps creates when it
+	// completes the pull request, as opposed to LastMergeSourceCommit,
+	// which is just the tip of the source branch.
+	LastMergeCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeCommit"`
+}
+
+type azureDevOpsPullRequestList struct {
+	Value []azureDevOpsPullRequest `json:"value"`
+}
+
+func (d *azureDevOpsDriver) LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error) {
+	result := PullRequestInfo{}
+	if d.token == "" {
+		return result, nil
+	}
+	pullRequests, err := d.findPullRequests(branch, parentBranch)
+	if err != nil {
+		return result, err
+	}
+	if len(pullRequests) != 1 {
+		return result, nil
+	}
+	result.CanMergeWithAPI = true
+	result.PullRequestNumber = pullRequests[0].PullRequestID
+	result.DefaultCommitMessage = fmt.Sprintf("%s (#%d)", pullRequests[0].Title, pullRequests[0].PullRequestID)
+	return result, nil
+}
+
+func (d *azureDevOpsDriver) findPullRequests(branch, parentBranch string) ([]azureDevOpsPullRequest, error) {
+	url := fmt.Sprintf(
+		"%s/pullrequests?searchCriteria.sourceRefName=refs/heads/%s&searchCriteria.targetRefName=refs/heads/%s&searchCriteria.status=active&api-version=6.0",
+		d.apiBaseURL(), branch, parentBranch,
+	)
+	var pullRequests azureDevOpsPullRequestList
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests.Value, err
+}
+
+func (d *azureDevOpsDriver) findChildPullRequests(branch string) ([]azureDevOpsPullRequest, error) {
+	url := fmt.Sprintf(
+		"%s/pullrequests?searchCriteria.targetRefName=refs/heads/%s&searchCriteria.status=active&api-version=6.0",
+		d.apiBaseURL(), branch,
+	)
+	var pullRequests azureDevOpsPullRequestList
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests.Value, err
+}
+
+func (d *azureDevOpsDriver) MergePullRequest(options MergePullRequestOptions) (string, error) {
+	childPRs, err := d.findChildPullRequests(options.Branch)
+	if err != nil {
+		return "", err
+	}
+	pullRequestID := options.PullRequestNumber
+	if pullRequestID == 0 {
+		pullRequests, err := d.findPullRequests(options.Branch, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+		if len(pullRequests) != 1 {
+			return "", fmt.Errorf("cannot merge via Azure DevOps since there is no pull request")
+		}
+		pullRequestID = pullRequests[0].PullRequestID
+	}
+	var pullRequest azureDevOpsPullRequest
+	err = d.request("GET", fmt.Sprintf("%s/pullrequests/%d?api-version=6.0", d.apiBaseURL(), pullRequestID), nil, &pullRequest)
+	if err != nil {
+		return "", err
+	}
+	body := map[string]interface{}{
+		"status":                "completed",
+		"lastMergeSourceCommit": pullRequest.LastMergeSourceCommit,
+		"completionOptions":     map[string]string{"mergeStrategy": azureDevOpsMergeStrategy(options.MergeStrategy)},
+	}
+	var response azureDevOpsPullRequest
+	err = d.request("PATCH", fmt.Sprintf("%s/pullrequests/%d?api-version=6.0", d.apiBaseURL(), pullRequestID), body, &response)
+	if err != nil {
+		return "", err
+	}
+	for _, childPR := range childPRs {
+		err = d.updatePullRequestTarget(childPR.PullRequestID, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+	}
+	return response.LastMergeCommit.CommitID, nil
+}
+
+// azureDevOpsMergeStrategy translates a configured merge strategy into the
+// "completionOptions.mergeStrategy" value accepted by Azure DevOps's pull
+// request API. Azure DevOps has no fast-forward merge strategy, so that
+// strategy falls back to a regular merge.
+func azureDevOpsMergeStrategy(mergeStrategy string) string {
+	switch mergeStrategy {
+	case MergeStrategyMerge, MergeStrategyFastForward:
+		return "noFastForward"
+	case MergeStrategyRebase:
+		return "rebase"
+	default:
+		return "squash"
+	}
+}
+
+func (d *azureDevOpsDriver) updatePullRequestTarget(id int64, newTargetBranch string) error {
+	url := fmt.Sprintf("%s/pullrequests/%d?api-version=6.0", d.apiBaseURL(), id)
+	body := map[string]string{"targetRefName": "refs/heads/" + newTargetBranch}
+	return d.request("PATCH", url, body, nil)
+}
+
+func (d *azureDevOpsDriver) request(method, url string, body interface{}, result interface{}) error {
+	return apiRequest("Azure DevOps", method, url, body, result, func(request *http.Request) {
+		if d.token != "" {
+			request.SetBasicAuth("", d.token)
+		}
+	})
+}
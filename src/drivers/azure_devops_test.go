@@ -0,0 +1,172 @@
+package drivers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/git-town/git-town/src/drivers"
+	"github.com/stretchr/testify/assert"
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+const azureRoot = "https://dev.azure.com/git-town/git-town/_apis/git/repositories/git-town"
+const azureCurrOpen = azureRoot + "/pullrequests?searchCriteria.sourceRefName=refs/heads/feature&searchCriteria.targetRefName=refs/heads/main&searchCriteria.status=active&api-version=6.0"
+const azureChildOpen = azureRoot + "/pullrequests?searchCriteria.targetRefName=refs/heads/feature&searchCriteria.status=active&api-version=6.0"
+const azurePR1 = azureRoot + "/pullrequests/1?api-version=6.0"
+const azurePR2 = azureRoot + "/pullrequests/2?api-version=6.0"
+const azurePR3 = azureRoot + "/pullrequests/3?api-version=6.0"
+
+func setupAzureDevOpsDriver(t *testing.T, token string) (drivers.CodeHostingDriver, func()) {
+	httpmock.Activate()
+	driver := drivers.LoadAzureDevOps(mockConfig{
+		remoteOriginURL:  "https://dev.azure.com/git-town/git-town/_git/git-town",
+		azureDevOpsToken: token,
+	}, log)
+	assert.NotNil(t, driver)
+	return driver, func() {
+		httpmock.DeactivateAndReset()
+	}
+}
+
+func TestLoadAzureDevOps(t *testing.T) {
+	driver := drivers.LoadAzureDevOps(mockConfig{
+		remoteOriginURL: "https://dev.azure.com/git-town/git-town/_git/git-town",
+	}, log)
+	assert.NotNil(t, driver)
+	assert.Equal(t, "Azure DevOps", driver.HostingServiceName())
+	assert.Equal(t, "https://dev.azure.com/git-town/git-town/_git/git-town", driver.RepositoryURL())
+}
+
+func TestLoadAzureDevOps_wrongDriverName(t *testing.T) {
+	driver := drivers.LoadAzureDevOps(mockConfig{
+		codeHostingDriverName: "github",
+		remoteOriginURL:       "https://dev.azure.com/git-town/git-town/_git/git-town",
+	}, log)
+	assert.Nil(t, driver)
+}
+
+func TestAzureDevOpsDriver_LoadPullRequestInfo_EmptyToken(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "")
+	defer teardown()
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestAzureDevOpsDriver_LoadPullRequestInfo_GetPullRequestNumberFails(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", azureCurrOpen, httpmock.NewStringResponder(404, ""))
+	_, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.Error(t, err)
+}
+
+func TestAzureDevOpsDriver_LoadPullRequestInfo_NoPullRequestForBranch(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", azureCurrOpen, httpmock.NewStringResponder(200, `{"value": []}`))
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestAzureDevOpsDriver_LoadPullRequestInfo_MultiplePullRequestsForBranch(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", azureCurrOpen, httpmock.NewStringResponder(200, `{"value": [{"pullRequestId": 1}, {"pullRequestId": 2}]}`))
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestAzureDevOpsDriver_MergePullRequest(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	var mergeRequest *http.Request
+	httpmock.RegisterResponder("GET", azureChildOpen, httpmock.NewStringResponder(200, `{"value": []}`))
+	httpmock.RegisterResponder("GET", azurePR1, httpmock.NewStringResponder(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}}`))
+	httpmock.RegisterResponder("PATCH", azurePR1, func(req *http.Request) (*http.Response, error) {
+		mergeRequest = req
+		return httpmock.NewStringResponse(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}, "lastMergeCommit": {"commitId": "abc123"}}`), nil
+	})
+	sha, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", sha)
+	mergeParameters := getRequestData(mergeRequest)
+	assert.Equal(t, "completed", mergeParameters["status"])
+}
+
+func TestAzureDevOpsDriver_MergePullRequest_MergeStrategy(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+		MergeStrategy:     drivers.MergeStrategyRebase,
+	}
+	var mergeRequest *http.Request
+	httpmock.RegisterResponder("GET", azureChildOpen, httpmock.NewStringResponder(200, `{"value": []}`))
+	httpmock.RegisterResponder("GET", azurePR1, httpmock.NewStringResponder(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}}`))
+	httpmock.RegisterResponder("PATCH", azurePR1, func(req *http.Request) (*http.Response, error) {
+		mergeRequest = req
+		return httpmock.NewStringResponse(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}, "lastMergeCommit": {"commitId": "abc123"}}`), nil
+	})
+	_, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	mergeParameters := getRequestData(mergeRequest)
+	completionOptions := mergeParameters["completionOptions"].(map[string]interface{})
+	assert.Equal(t, "rebase", completionOptions["mergeStrategy"])
+}
+
+func TestAzureDevOpsDriver_MergePullRequest_MergeFails(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	httpmock.RegisterResponder("GET", azureChildOpen, httpmock.NewStringResponder(200, `{"value": []}`))
+	httpmock.RegisterResponder("GET", azurePR1, httpmock.NewStringResponder(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}}`))
+	httpmock.RegisterResponder("PATCH", azurePR1, httpmock.NewStringResponder(404, ""))
+	_, err := driver.MergePullRequest(options)
+	assert.Error(t, err)
+}
+
+func TestAzureDevOpsDriver_MergePullRequest_UpdateChildPRs(t *testing.T) {
+	driver, teardown := setupAzureDevOpsDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	var updateRequest1, updateRequest2 *http.Request
+	httpmock.RegisterResponder("GET", azureChildOpen, httpmock.NewStringResponder(200, `{"value": [{"pullRequestId": 2}, {"pullRequestId": 3}]}`))
+	httpmock.RegisterResponder("GET", azurePR1, httpmock.NewStringResponder(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}}`))
+	httpmock.RegisterResponder("PATCH", azurePR1, httpmock.NewStringResponder(200, `{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "source123"}, "lastMergeCommit": {"commitId": "abc123"}}`))
+	httpmock.RegisterResponder("PATCH", azurePR2, func(req *http.Request) (*http.Response, error) {
+		updateRequest1 = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("PATCH", azurePR3, func(req *http.Request) (*http.Response, error) {
+		updateRequest2 = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	_, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	updateParameters1 := getRequestData(updateRequest1)
+	assert.Equal(t, "refs/heads/main", updateParameters1["targetRefName"])
+	updateParameters2 := getRequestData(updateRequest2)
+	assert.Equal(t, "refs/heads/main", updateParameters2["targetRefName"])
+}
@@ -0,0 +1,176 @@
+package drivers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type bitbucketDriver struct {
+	hostname string
+	org      string
+	repo     string
+	username string
+	password string
+	log      Log
+}
+
+func init() {
+	Register("bitbucket", func(config Config) bool { return getOriginHostname(config) == "bitbucket.org" }, LoadBitbucket)
+}
+
+// LoadBitbucket detects whether the current repository is hosted on Bitbucket
+// and if so, returns a driver that uses the Bitbucket API for its remote operations.
+func LoadBitbucket(config Config, log Log) CodeHostingDriver {
+	hostname := getOriginHostname(config)
+	if config.GetCodeHostingDriverName() != "" && config.GetCodeHostingDriverName() != "bitbucket" {
+		return nil
+	}
+	if config.GetCodeHostingDriverName() != "bitbucket" && hostname != "bitbucket.org" {
+		return nil
+	}
+	org, repo := parseOriginURL(config.GetRemoteOriginURL())
+	if org == "" || repo == "" {
+		return nil
+	}
+	return &bitbucketDriver{
+		hostname: hostname,
+		org:      org,
+		repo:     repo,
+		username: config.GetBitbucketUsername(),
+		password: config.GetBitbucketAppPassword(),
+		log:      log,
+	}
+}
+
+func (d *bitbucketDriver) HostingServiceName() string {
+	return "Bitbucket"
+}
+
+func (d *bitbucketDriver) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", d.hostname, d.org, d.repo)
+}
+
+func (d *bitbucketDriver) apiBaseURL() string {
+	return fmt.Sprintf("https://api.%s/2.0/repositories/%s/%s", d.hostname, d.org, d.repo)
+}
+
+type bitbucketPullRequest struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+}
+
+type bitbucketPullRequestList struct {
+	Values []bitbucketPullRequest `json:"values"`
+}
+
+func (d *bitbucketDriver) LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error) {
+	result := PullRequestInfo{}
+	if d.username == "" || d.password == "" {
+		return result, nil
+	}
+	pullRequests, err := d.findPullRequests(branch, parentBranch)
+	if err != nil {
+		return result, err
+	}
+	if len(pullRequests) != 1 {
+		return result, nil
+	}
+	result.CanMergeWithAPI = true
+	result.PullRequestNumber = pullRequests[0].ID
+	result.DefaultCommitMessage = fmt.Sprintf("%s (#%d)", pullRequests[0].Title, pullRequests[0].ID)
+	return result, nil
+}
+
+func (d *bitbucketDriver) findPullRequests(branch, parentBranch string) ([]bitbucketPullRequest, error) {
+	query := fmt.Sprintf(`state="OPEN" AND source.branch.name="%s" AND destination.branch.name="%s"`, branch, parentBranch)
+	url := fmt.Sprintf("%s/pullrequests?q=%s", d.apiBaseURL(), query)
+	var pullRequests bitbucketPullRequestList
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests.Values, err
+}
+
+func (d *bitbucketDriver) findChildPullRequests(branch string) ([]bitbucketPullRequest, error) {
+	query := fmt.Sprintf(`state="OPEN" AND destination.branch.name="%s"`, branch)
+	url := fmt.Sprintf("%s/pullrequests?q=%s", d.apiBaseURL(), query)
+	var pullRequests bitbucketPullRequestList
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests.Values, err
+}
+
+func (d *bitbucketDriver) MergePullRequest(options MergePullRequestOptions) (string, error) {
+	childPRs, err := d.findChildPullRequests(options.Branch)
+	if err != nil {
+		return "", err
+	}
+	pullRequestID := options.PullRequestNumber
+	if pullRequestID == 0 {
+		pullRequests, err := d.findPullRequests(options.Branch, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+		if len(pullRequests) != 1 {
+			return "", fmt.Errorf("cannot merge via Bitbucket since there is no pull request")
+		}
+		pullRequestID = pullRequests[0].ID
+	}
+	commitTitle, commitMessage := splitCommitMessage(options.CommitMessage)
+	body := map[string]string{
+		"type":           "pullrequest",
+		"message":        commitTitle + "\n\n" + commitMessage,
+		"merge_strategy": bitbucketMergeStrategy(options.MergeStrategy),
+	}
+	var response struct {
+		MergeCommit struct {
+			Hash string `json:"hash"`
+		} `json:"merge_commit"`
+	}
+	url := fmt.Sprintf("%s/pullrequests/%d/merge", d.apiBaseURL(), pullRequestID)
+	err = d.request("POST", url, body, &response)
+	if err != nil {
+		return "", err
+	}
+	for _, childPR := range childPRs {
+		err = d.updatePullRequestDestination(childPR.ID, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+	}
+	return response.MergeCommit.Hash, nil
+}
+
+// bitbucketMergeStrategy translates a configured merge strategy into the
+// "merge_strategy" value accepted by Bitbucket's merge endpoint. Bitbucket
+// has no dedicated rebase merge strategy, so it falls back to a regular merge.
+func bitbucketMergeStrategy(mergeStrategy string) string {
+	switch mergeStrategy {
+	case MergeStrategyMerge, MergeStrategyRebase:
+		return "merge_commit"
+	case MergeStrategyFastForward:
+		return "fast_forward"
+	default:
+		return "squash"
+	}
+}
+
+func (d *bitbucketDriver) updatePullRequestDestination(id int64, newBase string) error {
+	url := fmt.Sprintf("%s/pullrequests/%d", d.apiBaseURL(), id)
+	body := map[string]interface{}{
+		"destination": map[string]interface{}{
+			"branch": map[string]string{"name": newBase},
+		},
+	}
+	return d.request("PUT", url, body, nil)
+}
+
+func (d *bitbucketDriver) request(method, url string, body interface{}, result interface{}) error {
+	return apiRequest("Bitbucket", method, url, body, result, func(request *http.Request) {
+		if d.username != "" {
+			request.SetBasicAuth(d.username, d.password)
+		}
+	})
+}
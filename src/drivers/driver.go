@@ -0,0 +1,72 @@
+package drivers
+
+// CodeHostingDriver defines the interface for code hosting drivers.
+// Git Town uses these to look up and merge pull requests through
+// the respective code hosting provider's API.
+type CodeHostingDriver interface {
+	// HostingServiceName returns the name of the code hosting service,
+	// e.g. "GitHub" or "GitLab".
+	HostingServiceName() string
+
+	// RepositoryURL returns the URL where the repository can be browsed online.
+	RepositoryURL() string
+
+	// LoadPullRequestInfo returns the information required to merge
+	// the pull request for the given branch into the given parent branch.
+	LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error)
+
+	// MergePullRequest merges the pull request through the API of the
+	// code hosting provider and returns the SHA of the merge commit.
+	MergePullRequest(options MergePullRequestOptions) (mergeSha string, err error)
+}
+
+// PullRequestInfo describes a pull request that Git Town found for a branch.
+type PullRequestInfo struct {
+	CanMergeWithAPI      bool
+	DefaultCommitMessage string
+	PullRequestNumber    int64
+}
+
+// MergePullRequestOptions are the options used to merge a pull request.
+type MergePullRequestOptions struct {
+	Branch            string
+	PullRequestNumber int64
+	CommitMessage     string
+	ParentBranch      string
+	// MergeStrategy is one of MergeStrategySquash, MergeStrategyMerge,
+	// MergeStrategyRebase, or MergeStrategyFastForward. An empty value means
+	// squash. Drivers that don't support a requested strategy use the closest
+	// one they have, usually a regular merge; see each driver for details.
+	MergeStrategy string
+}
+
+// The merge strategies that can be configured via
+// "git-town.pull-request-merge-strategy" and overridden with
+// "ship --merge-strategy".
+const (
+	MergeStrategySquash      = "squash"
+	MergeStrategyMerge       = "merge"
+	MergeStrategyRebase      = "rebase"
+	MergeStrategyFastForward = "fast-forward"
+)
+
+// Config defines the configuration data that drivers need
+// in order to detect and authenticate against a code hosting service.
+type Config interface {
+	GetRemoteOriginURL() string
+	GetCodeHostingDriverName() string
+	GetCodeHostingOriginHostname() string
+	GetGitHubToken() string
+	GetGitLabToken() string
+	GetBitbucketUsername() string
+	GetBitbucketAppPassword() string
+	GetAzureDevOpsToken() string
+	GetGiteaToken() string
+	GetPullRequestMergeStrategy() string
+}
+
+// Log defines the minimal logging interface that drivers use to print
+// information about the API requests they make.
+type Log interface {
+	Printf(format string, v ...interface{})
+}
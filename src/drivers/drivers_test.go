@@ -0,0 +1,31 @@
+package drivers_test
+
+type mockConfig struct {
+	remoteOriginURL       string
+	codeHostingDriverName string
+	configuredHostName    string
+	gitHubToken           string
+	gitLabToken           string
+	bitbucketUsername     string
+	bitbucketAppPassword  string
+	azureDevOpsToken      string
+	giteaToken            string
+	mergeStrategy         string
+}
+
+func (c mockConfig) GetRemoteOriginURL() string          { return c.remoteOriginURL }
+func (c mockConfig) GetCodeHostingDriverName() string    { return c.codeHostingDriverName }
+func (c mockConfig) GetCodeHostingOriginHostname() string { return c.configuredHostName }
+func (c mockConfig) GetGitHubToken() string              { return c.gitHubToken }
+func (c mockConfig) GetGitLabToken() string              { return c.gitLabToken }
+func (c mockConfig) GetBitbucketUsername() string        { return c.bitbucketUsername }
+func (c mockConfig) GetBitbucketAppPassword() string     { return c.bitbucketAppPassword }
+func (c mockConfig) GetAzureDevOpsToken() string         { return c.azureDevOpsToken }
+func (c mockConfig) GetGiteaToken() string                { return c.giteaToken }
+func (c mockConfig) GetPullRequestMergeStrategy() string   { return c.mergeStrategy }
+
+type testLog struct{}
+
+func (testLog) Printf(format string, v ...interface{}) {}
+
+var log = testLog{}
@@ -0,0 +1,184 @@
+package drivers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type giteaDriver struct {
+	hostname string
+	org      string
+	repo     string
+	token    string
+	log      Log
+}
+
+// LoadGitea detects whether the current repository is hosted on a Gitea
+// instance and if so, returns a driver that uses the Gitea API for its
+// remote operations. Since Gitea is commonly self-hosted, detection relies
+// on an explicit "code-hosting-driver-name: gitea" config entry or a
+// configured hostname rather than matching a fixed domain.
+func LoadGitea(config Config, log Log) CodeHostingDriver {
+	driverName := config.GetCodeHostingDriverName()
+	if driverName != "" {
+		if driverName != "gitea" {
+			return nil
+		}
+	} else if config.GetCodeHostingOriginHostname() == "" {
+		return nil
+	}
+	hostname := getOriginHostname(config)
+	org, repo := parseOriginURL(config.GetRemoteOriginURL())
+	if hostname == "" || org == "" || repo == "" {
+		return nil
+	}
+	return &giteaDriver{
+		hostname: hostname,
+		org:      org,
+		repo:     repo,
+		token:    config.GetGiteaToken(),
+		log:      log,
+	}
+}
+
+// knownHostingDomains are the SaaS domains that already have their own
+// dedicated driver, so an explicitly configured hostname pointing at one of
+// them must never be claimed by the Gitea fallback below.
+var knownHostingDomains = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+func init() {
+	// Gitea is virtually always self-hosted under an arbitrary domain, so it
+	// can't be auto-detected from the remote URL the way github.com or
+	// gitlab.com can. Instead, it's selected either explicitly via
+	// "code-hosting-driver-name: gitea" or implicitly whenever the user has
+	// pointed "configured-host-name" at a domain none of the other drivers own.
+	Register("gitea", func(config Config) bool {
+		hostname := config.GetCodeHostingOriginHostname()
+		return hostname != "" && !knownHostingDomains[hostname]
+	}, LoadGitea)
+}
+
+func (d *giteaDriver) HostingServiceName() string {
+	return "Gitea"
+}
+
+func (d *giteaDriver) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", d.hostname, d.org, d.repo)
+}
+
+func (d *giteaDriver) apiBaseURL() string {
+	return fmt.Sprintf("https://%s/api/v1/repos/%s/%s", d.hostname, d.org, d.repo)
+}
+
+type giteaPullRequest struct {
+	Index          int64  `json:"number"`
+	Title          string `json:"title"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+}
+
+func (d *giteaDriver) LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error) {
+	result := PullRequestInfo{}
+	if d.token == "" {
+		return result, nil
+	}
+	pullRequests, err := d.findPullRequests(branch, parentBranch)
+	if err != nil {
+		return result, err
+	}
+	if len(pullRequests) != 1 {
+		return result, nil
+	}
+	result.CanMergeWithAPI = true
+	result.PullRequestNumber = pullRequests[0].Index
+	result.DefaultCommitMessage = fmt.Sprintf("%s (#%d)", pullRequests[0].Title, pullRequests[0].Index)
+	return result, nil
+}
+
+func (d *giteaDriver) findPullRequests(branch, parentBranch string) ([]giteaPullRequest, error) {
+	url := fmt.Sprintf("%s/pulls?state=open&head=%s:%s&base=%s", d.apiBaseURL(), d.org, branch, parentBranch)
+	var pullRequests []giteaPullRequest
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests, err
+}
+
+func (d *giteaDriver) findChildPullRequests(branch string) ([]giteaPullRequest, error) {
+	url := fmt.Sprintf("%s/pulls?state=open&base=%s", d.apiBaseURL(), branch)
+	var pullRequests []giteaPullRequest
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests, err
+}
+
+func (d *giteaDriver) MergePullRequest(options MergePullRequestOptions) (string, error) {
+	childPRs, err := d.findChildPullRequests(options.Branch)
+	if err != nil {
+		return "", err
+	}
+	pullRequestIndex := options.PullRequestNumber
+	if pullRequestIndex == 0 {
+		pullRequests, err := d.findPullRequests(options.Branch, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+		if len(pullRequests) != 1 {
+			return "", fmt.Errorf("cannot merge via Gitea since there is no pull request")
+		}
+		pullRequestIndex = pullRequests[0].Index
+	}
+	commitTitle, commitMessage := splitCommitMessage(options.CommitMessage)
+	body := map[string]string{
+		"Do":                giteaMergeStyle(options.MergeStrategy),
+		"MergeTitleField":   commitTitle,
+		"MergeMessageField": commitMessage,
+	}
+	mergeURL := fmt.Sprintf("%s/pulls/%d/merge", d.apiBaseURL(), pullRequestIndex)
+	err = d.request("POST", mergeURL, body, nil)
+	if err != nil {
+		return "", err
+	}
+	// Gitea's merge endpoint returns no body on success, so the merge SHA
+	// has to be read back from the pull request afterwards.
+	var mergedPR giteaPullRequest
+	err = d.request("GET", fmt.Sprintf("%s/pulls/%d", d.apiBaseURL(), pullRequestIndex), nil, &mergedPR)
+	if err != nil {
+		return "", err
+	}
+	for _, childPR := range childPRs {
+		err = d.updatePullRequestBase(childPR.Index, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+	}
+	return mergedPR.MergeCommitSHA, nil
+}
+
+// giteaMergeStyle translates a configured merge strategy into the "Do"
+// value accepted by Gitea's merge endpoint. Gitea has no fast-forward
+// merge style, so that strategy falls back to a regular merge.
+func giteaMergeStyle(mergeStrategy string) string {
+	switch mergeStrategy {
+	case MergeStrategyMerge, MergeStrategyFastForward:
+		return "merge"
+	case MergeStrategyRebase:
+		return "rebase"
+	default:
+		return "squash"
+	}
+}
+
+func (d *giteaDriver) updatePullRequestBase(index int64, newBase string) error {
+	url := fmt.Sprintf("%s/pulls/%d", d.apiBaseURL(), index)
+	body := map[string]string{"base": newBase}
+	return d.request("PATCH", url, body, nil)
+}
+
+func (d *giteaDriver) request(method, url string, body interface{}, result interface{}) error {
+	return apiRequest("Gitea", method, url, body, result, func(request *http.Request) {
+		if d.token != "" {
+			request.Header.Set("Authorization", "token "+d.token)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package drivers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/git-town/git-town/src/drivers"
+	"github.com/stretchr/testify/assert"
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+const giteaRoot = "https://gitea.example.com/api/v1/repos/git-town/git-town"
+const giteaCurrOpen = giteaRoot + "/pulls?state=open&head=git-town:feature&base=main"
+const giteaChildOpen = giteaRoot + "/pulls?state=open&base=feature"
+const giteaPR1 = giteaRoot + "/pulls/1"
+const giteaPR2 = giteaRoot + "/pulls/2"
+const giteaPR3 = giteaRoot + "/pulls/3"
+const giteaPR1Merge = giteaRoot + "/pulls/1/merge"
+
+func setupGiteaDriver(t *testing.T, token string) (drivers.CodeHostingDriver, func()) {
+	httpmock.Activate()
+	driver := drivers.LoadGitea(mockConfig{
+		codeHostingDriverName: "gitea",
+		configuredHostName:    "gitea.example.com",
+		remoteOriginURL:       "git@gitea.example.com:git-town/git-town.git",
+		giteaToken:            token,
+	}, log)
+	assert.NotNil(t, driver)
+	return driver, func() {
+		httpmock.DeactivateAndReset()
+	}
+}
+
+func TestLoadGitea(t *testing.T) {
+	driver := drivers.LoadGitea(mockConfig{
+		codeHostingDriverName: "gitea",
+		configuredHostName:    "gitea.example.com",
+		remoteOriginURL:       "git@gitea.example.com:git-town/git-town.git",
+	}, log)
+	assert.NotNil(t, driver)
+	assert.Equal(t, "Gitea", driver.HostingServiceName())
+	assert.Equal(t, "https://gitea.example.com/git-town/git-town", driver.RepositoryURL())
+}
+
+func TestLoadGitea_noDriverNameOrConfiguredHost(t *testing.T) {
+	driver := drivers.LoadGitea(mockConfig{
+		remoteOriginURL: "git@gitea.example.com:git-town/git-town.git",
+	}, log)
+	assert.Nil(t, driver)
+}
+
+func TestLoad_configuredHostNameDetectsGitea(t *testing.T) {
+	driver, err := drivers.Load(mockConfig{
+		remoteOriginURL:    "git@gitea.example.com:git-town/git-town.git",
+		configuredHostName: "gitea.example.com",
+	}, log)
+	assert.NoError(t, err)
+	assert.NotNil(t, driver)
+	assert.Equal(t, "Gitea", driver.HostingServiceName())
+}
+
+func TestGiteaDriver_LoadPullRequestInfo_EmptyGiteaToken(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "")
+	defer teardown()
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestGiteaDriver_LoadPullRequestInfo_GetPullRequestNumberFails(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", giteaCurrOpen, httpmock.NewStringResponder(404, ""))
+	_, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.Error(t, err)
+}
+
+func TestGiteaDriver_LoadPullRequestInfo_NoPullRequestForBranch(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", giteaCurrOpen, httpmock.NewStringResponder(200, "[]"))
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestGiteaDriver_LoadPullRequestInfo_MultiplePullRequestsForBranch(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	httpmock.RegisterResponder("GET", giteaCurrOpen, httpmock.NewStringResponder(200, `[{"number": 1}, {"number": 2}]`))
+	prInfo, err := driver.LoadPullRequestInfo("feature", "main")
+	assert.NoError(t, err)
+	assert.False(t, prInfo.CanMergeWithAPI)
+}
+
+func TestGiteaDriver_MergePullRequest(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	var mergeRequest *http.Request
+	httpmock.RegisterResponder("GET", giteaChildOpen, httpmock.NewStringResponder(200, "[]"))
+	httpmock.RegisterResponder("POST", giteaPR1Merge, func(req *http.Request) (*http.Response, error) {
+		mergeRequest = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("GET", giteaPR1, httpmock.NewStringResponder(200, `{"number": 1, "merge_commit_sha": "abc123"}`))
+	sha, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", sha)
+	mergeParameters := getRequestData(mergeRequest)
+	assert.Equal(t, "title", mergeParameters["MergeTitleField"])
+	assert.Equal(t, "extra detail1\nextra detail2", mergeParameters["MergeMessageField"])
+	assert.Equal(t, "squash", mergeParameters["Do"])
+}
+
+func TestGiteaDriver_MergePullRequest_MergeStrategy(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+		MergeStrategy:     drivers.MergeStrategyRebase,
+	}
+	var mergeRequest *http.Request
+	httpmock.RegisterResponder("GET", giteaChildOpen, httpmock.NewStringResponder(200, "[]"))
+	httpmock.RegisterResponder("POST", giteaPR1Merge, func(req *http.Request) (*http.Response, error) {
+		mergeRequest = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("GET", giteaPR1, httpmock.NewStringResponder(200, `{"number": 1, "merge_commit_sha": "abc123"}`))
+	_, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	mergeParameters := getRequestData(mergeRequest)
+	assert.Equal(t, "rebase", mergeParameters["Do"])
+}
+
+func TestGiteaDriver_MergePullRequest_MergeFails(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	httpmock.RegisterResponder("GET", giteaChildOpen, httpmock.NewStringResponder(200, "[]"))
+	httpmock.RegisterResponder("POST", giteaPR1Merge, httpmock.NewStringResponder(404, ""))
+	_, err := driver.MergePullRequest(options)
+	assert.Error(t, err)
+}
+
+func TestGiteaDriver_MergePullRequest_UpdateChildPRs(t *testing.T) {
+	driver, teardown := setupGiteaDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+	}
+	var updateRequest1, updateRequest2 *http.Request
+	httpmock.RegisterResponder("GET", giteaChildOpen, httpmock.NewStringResponder(200, `[{"number": 2}, {"number": 3}]`))
+	httpmock.RegisterResponder("PATCH", giteaPR2, func(req *http.Request) (*http.Response, error) {
+		updateRequest1 = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("PATCH", giteaPR3, func(req *http.Request) (*http.Response, error) {
+		updateRequest2 = req
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("POST", giteaPR1Merge, httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("GET", giteaPR1, httpmock.NewStringResponder(200, `{"number": 1, "merge_commit_sha": "abc123"}`))
+	_, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	updateParameters1 := getRequestData(updateRequest1)
+	assert.Equal(t, "main", updateParameters1["base"])
+	updateParameters2 := getRequestData(updateRequest2)
+	assert.Equal(t, "main", updateParameters2["base"])
+}
@@ -0,0 +1,197 @@
+package drivers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type githubDriver struct {
+	originURL string
+	hostname  string
+	org       string
+	repo      string
+	token     string
+	log       Log
+}
+
+var githubOriginURLRegex = regexp.MustCompile(`(?:^|@|//)([^@/:]+)[:/]([^/]+)/([^/]+?)(?:\.git)?$`)
+
+func init() {
+	Register("github", func(config Config) bool { return getOriginHostname(config) == "github.com" }, LoadGithub)
+}
+
+// LoadGithub detects whether the current repository is hosted on GitHub
+// and if so, returns a driver that uses the GitHub API for its remote operations.
+func LoadGithub(config Config, log Log) CodeHostingDriver {
+	hostname := getOriginHostname(config)
+	if config.GetCodeHostingDriverName() != "" && config.GetCodeHostingDriverName() != "github" {
+		return nil
+	}
+	if config.GetCodeHostingDriverName() != "github" && hostname != "github.com" {
+		return nil
+	}
+	org, repo := parseOriginURL(config.GetRemoteOriginURL())
+	if org == "" || repo == "" {
+		return nil
+	}
+	return &githubDriver{
+		originURL: config.GetRemoteOriginURL(),
+		hostname:  hostname,
+		org:       org,
+		repo:      repo,
+		token:     config.GetGitHubToken(),
+		log:       log,
+	}
+}
+
+func (d *githubDriver) HostingServiceName() string {
+	return "GitHub"
+}
+
+func (d *githubDriver) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", d.hostname, d.org, d.repo)
+}
+
+func (d *githubDriver) apiBaseURL() string {
+	return fmt.Sprintf("https://api.%s/repos/%s/%s", d.hostname, d.org, d.repo)
+}
+
+type githubPullRequest struct {
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+	Base   struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (d *githubDriver) LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error) {
+	result := PullRequestInfo{}
+	if d.token == "" {
+		return result, nil
+	}
+	pullRequests, err := d.findPullRequests(branch, parentBranch)
+	if err != nil {
+		return result, err
+	}
+	if len(pullRequests) != 1 {
+		return result, nil
+	}
+	result.CanMergeWithAPI = true
+	result.PullRequestNumber = pullRequests[0].Number
+	result.DefaultCommitMessage = fmt.Sprintf("%s (#%d)", pullRequests[0].Title, pullRequests[0].Number)
+	return result, nil
+}
+
+func (d *githubDriver) findPullRequests(branch, parentBranch string) ([]githubPullRequest, error) {
+	url := fmt.Sprintf("%s/pulls?base=%s&head=%s:%s&state=open", d.apiBaseURL(), parentBranch, d.org, branch)
+	var pullRequests []githubPullRequest
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests, err
+}
+
+func (d *githubDriver) findChildPullRequests(branch string) ([]githubPullRequest, error) {
+	url := fmt.Sprintf("%s/pulls?base=%s&state=open", d.apiBaseURL(), branch)
+	var pullRequests []githubPullRequest
+	err := d.request("GET", url, nil, &pullRequests)
+	return pullRequests, err
+}
+
+func (d *githubDriver) MergePullRequest(options MergePullRequestOptions) (string, error) {
+	childPRs, err := d.findChildPullRequests(options.Branch)
+	if err != nil {
+		return "", err
+	}
+	pullRequestNumber := options.PullRequestNumber
+	if pullRequestNumber == 0 {
+		pullRequests, err := d.findPullRequests(options.Branch, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+		if len(pullRequests) != 1 {
+			return "", fmt.Errorf("cannot merge via Github since there is no pull request")
+		}
+		pullRequestNumber = pullRequests[0].Number
+	}
+	mergeStrategy := options.MergeStrategy
+	if mergeStrategy == "" {
+		mergeStrategy = MergeStrategySquash
+	}
+	body := map[string]string{"merge_method": githubMergeMethod(mergeStrategy)}
+	if mergeStrategy == MergeStrategySquash {
+		commitTitle, commitMessage := splitCommitMessage(options.CommitMessage)
+		body["commit_title"] = commitTitle
+		body["commit_message"] = commitMessage
+	}
+	var response struct {
+		SHA string `json:"sha"`
+	}
+	url := fmt.Sprintf("%s/pulls/%d/merge", d.apiBaseURL(), pullRequestNumber)
+	err = d.request("PUT", url, body, &response)
+	if err != nil {
+		return "", err
+	}
+	for _, childPR := range childPRs {
+		err = d.updatePullRequestBase(childPR.Number, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+	}
+	return response.SHA, nil
+}
+
+func (d *githubDriver) updatePullRequestBase(number int64, newBase string) error {
+	url := fmt.Sprintf("%s/pulls/%d", d.apiBaseURL(), number)
+	body := map[string]string{"base": newBase}
+	return d.request("PATCH", url, body, nil)
+}
+
+func (d *githubDriver) request(method, url string, body interface{}, result interface{}) error {
+	return apiRequest("GitHub", method, url, body, result, func(request *http.Request) {
+		if d.token != "" {
+			request.Header.Set("Authorization", "token "+d.token)
+		}
+	})
+}
+
+// githubMergeMethod translates a configured merge strategy into the
+// "merge_method" value accepted by GitHub's merge endpoint. GitHub has no
+// fast-forward merge method, so that strategy falls back to a regular merge.
+func githubMergeMethod(mergeStrategy string) string {
+	switch mergeStrategy {
+	case MergeStrategyMerge, MergeStrategyFastForward:
+		return "merge"
+	case MergeStrategyRebase:
+		return "rebase"
+	default:
+		return "squash"
+	}
+}
+
+func getOriginHostname(config Config) string {
+	if config.GetCodeHostingOriginHostname() != "" {
+		return config.GetCodeHostingOriginHostname()
+	}
+	matches := githubOriginURLRegex.FindStringSubmatch(config.GetRemoteOriginURL())
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
+func parseOriginURL(originURL string) (org, repo string) {
+	matches := githubOriginURLRegex.FindStringSubmatch(originURL)
+	if matches == nil {
+		return "", ""
+	}
+	return matches[2], matches[3]
+}
+
+func splitCommitMessage(message string) (title, body string) {
+	parts := strings.SplitN(message, "\n", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -162,6 +162,29 @@ func TestGitHubDriver_MergePullRequest(t *testing.T) {
 	assert.Equal(t, "squash", mergeParameters["merge_method"])
 }
 
+func TestGitHubDriver_MergePullRequest_MergeStrategy(t *testing.T) {
+	driver, teardown := setupGithubDriver(t, "TOKEN")
+	defer teardown()
+	options := drivers.MergePullRequestOptions{
+		Branch:            "feature",
+		PullRequestNumber: 1,
+		CommitMessage:     "title\nextra detail1\nextra detail2",
+		ParentBranch:      "main",
+		MergeStrategy:     drivers.MergeStrategyMerge,
+	}
+	var mergeRequest *http.Request
+	httpmock.RegisterResponder("GET", githubChildOpen, httpmock.NewStringResponder(200, "[]"))
+	httpmock.RegisterResponder("PUT", githubPR1Merge, func(req *http.Request) (*http.Response, error) {
+		mergeRequest = req
+		return httpmock.NewStringResponse(200, `{"sha": "abc123"}`), nil
+	})
+	_, err := driver.MergePullRequest(options)
+	assert.NoError(t, err)
+	mergeParameters := getRequestData(mergeRequest)
+	assert.Equal(t, "merge", mergeParameters["merge_method"])
+	assert.NotContains(t, mergeParameters, "commit_title")
+}
+
 func TestGitHubDriver_MergePullRequest_MergeFails(t *testing.T) {
 	driver, teardown := setupGithubDriver(t, "TOKEN")
 	defer teardown()
@@ -0,0 +1,131 @@
+package drivers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type gitlabDriver struct {
+	hostname string
+	org      string
+	repo     string
+	token    string
+	log      Log
+}
+
+// LoadGitlab detects whether the current repository is hosted on GitLab
+// and if so, returns a driver that uses the GitLab API for its remote operations.
+func LoadGitlab(config Config, log Log) CodeHostingDriver {
+	hostname := getOriginHostname(config)
+	if config.GetCodeHostingDriverName() != "" && config.GetCodeHostingDriverName() != "gitlab" {
+		return nil
+	}
+	if config.GetCodeHostingDriverName() != "gitlab" && hostname != "gitlab.com" {
+		return nil
+	}
+	org, repo := parseOriginURL(config.GetRemoteOriginURL())
+	if org == "" || repo == "" {
+		return nil
+	}
+	return &gitlabDriver{
+		hostname: hostname,
+		org:      org,
+		repo:     repo,
+		token:    config.GetGitLabToken(),
+		log:      log,
+	}
+}
+
+func init() {
+	Register("gitlab", func(config Config) bool { return getOriginHostname(config) == "gitlab.com" }, LoadGitlab)
+}
+
+func (d *gitlabDriver) HostingServiceName() string {
+	return "GitLab"
+}
+
+func (d *gitlabDriver) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", d.hostname, d.org, d.repo)
+}
+
+func (d *gitlabDriver) projectPath() string {
+	return url.QueryEscape(fmt.Sprintf("%s/%s", d.org, d.repo))
+}
+
+func (d *gitlabDriver) apiBaseURL() string {
+	return fmt.Sprintf("https://%s/api/v4/projects/%s", d.hostname, d.projectPath())
+}
+
+type gitlabMergeRequest struct {
+	IID   int64  `json:"iid"`
+	Title string `json:"title"`
+}
+
+func (d *gitlabDriver) LoadPullRequestInfo(branch, parentBranch string) (PullRequestInfo, error) {
+	result := PullRequestInfo{}
+	if d.token == "" {
+		return result, nil
+	}
+	mergeRequests, err := d.findMergeRequests(branch, parentBranch)
+	if err != nil {
+		return result, err
+	}
+	if len(mergeRequests) != 1 {
+		return result, nil
+	}
+	result.CanMergeWithAPI = true
+	result.PullRequestNumber = mergeRequests[0].IID
+	result.DefaultCommitMessage = fmt.Sprintf("%s (!%d)", mergeRequests[0].Title, mergeRequests[0].IID)
+	return result, nil
+}
+
+func (d *gitlabDriver) findMergeRequests(branch, parentBranch string) ([]gitlabMergeRequest, error) {
+	reqURL := fmt.Sprintf("%s/merge_requests?source_branch=%s&target_branch=%s&state=opened", d.apiBaseURL(), branch, parentBranch)
+	var mergeRequests []gitlabMergeRequest
+	err := d.request("GET", reqURL, nil, &mergeRequests)
+	return mergeRequests, err
+}
+
+func (d *gitlabDriver) MergePullRequest(options MergePullRequestOptions) (string, error) {
+	mergeRequestIID := options.PullRequestNumber
+	if mergeRequestIID == 0 {
+		mergeRequests, err := d.findMergeRequests(options.Branch, options.ParentBranch)
+		if err != nil {
+			return "", err
+		}
+		if len(mergeRequests) != 1 {
+			return "", fmt.Errorf("cannot merge via GitLab since there is no merge request")
+		}
+		mergeRequestIID = mergeRequests[0].IID
+	}
+	mergeStrategy := options.MergeStrategy
+	if mergeStrategy == "" {
+		mergeStrategy = MergeStrategySquash
+	}
+	commitTitle, commitMessage := splitCommitMessage(options.CommitMessage)
+	// GitLab has no first-class rebase or fast-forward merge method on this
+	// endpoint, so both fall back to a regular (non-squash) merge, the same
+	// way "merge" does.
+	body := map[string]interface{}{
+		"squash":                       mergeStrategy == MergeStrategySquash,
+		"should_remove_source_branch":  true,
+		"merge_when_pipeline_succeeds": true,
+	}
+	if mergeStrategy == MergeStrategySquash {
+		body["squash_commit_message"] = commitMessage
+		body["merge_commit_message"] = commitTitle
+	}
+	var response struct {
+		SHA string `json:"sha"`
+	}
+	reqURL := fmt.Sprintf("%s/merge_requests/%d/merge", d.apiBaseURL(), mergeRequestIID)
+	err := d.request("PUT", reqURL, body, &response)
+	return response.SHA, err
+}
+
+func (d *gitlabDriver) request(method, reqURL string, body interface{}, result interface{}) error {
+	return apiRequest("GitLab", method, reqURL, body, result, func(request *http.Request) {
+		request.Header.Set("PRIVATE-TOKEN", d.token)
+	})
+}
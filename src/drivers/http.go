@@ -0,0 +1,58 @@
+package drivers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// authorizer sets whatever authentication a code hosting API expects on the
+// outgoing request, e.g. a bearer token header or HTTP basic auth. Most
+// drivers' authorizers do nothing when no credentials are configured; the
+// GitLab one always sets its token header.
+type authorizer func(*http.Request)
+
+// apiRequest sends an HTTP request to a code hosting API and decodes its
+// JSON response into result, if given. Each driver's own "request" method
+// is a thin wrapper around this that supplies its service name (for error
+// messages) and its authentication scheme, so the marshal/unmarshal and
+// error-status handling below can't drift between drivers.
+func apiRequest(serviceName, method, url string, body interface{}, result interface{}, authorize authorizer) error {
+	var bodyReader *bytes.Reader
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+	} else {
+		bodyReader = bytes.NewReader(nil)
+	}
+	request, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	authorize(request)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("%s API returned error status %d for %s %s", serviceName, response.StatusCode, method, url)
+	}
+	if result == nil {
+		return nil
+	}
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(responseBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
@@ -0,0 +1,69 @@
+package drivers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DriverFactory creates a CodeHostingDriver from the given configuration.
+// It may return nil if, upon closer inspection, the configuration doesn't
+// actually match the driver after all.
+type DriverFactory func(config Config, log Log) CodeHostingDriver
+
+// HostMatcher decides whether a driver applies to the repository described
+// by the given configuration, based on its remote origin URL or configured
+// hostname. It is only consulted when the user hasn't pinned a driver via
+// the "code-hosting-driver-name" config key.
+type HostMatcher func(config Config) bool
+
+type registryEntry struct {
+	name        string
+	matchesHost HostMatcher
+	factory     DriverFactory
+}
+
+var registrations []registryEntry
+
+// Register adds a code hosting driver to the registry so that Load can find
+// it, either by its explicit name (via "code-hosting-driver-name") or by
+// matching the repository's remote origin. Third-party forks can call this
+// from an init function to plug in drivers without patching Git Town itself.
+func Register(name string, matchesHost HostMatcher, factory DriverFactory) {
+	registrations = append(registrations, registryEntry{name: name, matchesHost: matchesHost, factory: factory})
+}
+
+// Load detects which code hosting driver applies to the current repository
+// and returns it. An explicit "code-hosting-driver-name" config value always
+// wins; otherwise the registered drivers are consulted in registration order
+// and the first one whose HostMatcher matches is used.
+func Load(config Config, log Log) (CodeHostingDriver, error) {
+	driverName := config.GetCodeHostingDriverName()
+	if driverName != "" {
+		for _, reg := range registrations {
+			if reg.name == driverName {
+				return reg.factory(config, log), nil
+			}
+		}
+		return nil, fmt.Errorf("no driver registered for host %q. Registered drivers: %s", driverName, registeredNames())
+	}
+	for _, reg := range registrations {
+		if reg.matchesHost(config) {
+			return reg.factory(config, log), nil
+		}
+	}
+	hostname := getOriginHostname(config)
+	if hostname == "" {
+		// The repository has no remote or configured hosting information at all,
+		// which simply means Git Town can't offer API-assisted operations here.
+		return nil, nil
+	}
+	return nil, fmt.Errorf("no driver registered for host %q. Registered drivers: %s", hostname, registeredNames())
+}
+
+func registeredNames() string {
+	names := make([]string, len(registrations))
+	for i, reg := range registrations {
+		names[i] = reg.name
+	}
+	return strings.Join(names, ", ")
+}
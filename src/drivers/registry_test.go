@@ -0,0 +1,53 @@
+package drivers_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/src/drivers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_explicitDriverNameWinsOverURLDetection(t *testing.T) {
+	driver, err := drivers.Load(mockConfig{
+		codeHostingDriverName: "gitlab",
+		remoteOriginURL:       "git@github.com:git-town/git-town.git",
+	}, log)
+	assert.NoError(t, err)
+	assert.NotNil(t, driver)
+	assert.Equal(t, "GitLab", driver.HostingServiceName())
+}
+
+func TestLoad_configuredHostNameOverridesActualHost(t *testing.T) {
+	driver, err := drivers.Load(mockConfig{
+		remoteOriginURL:    "git@my-ssh-identity.com:git-town/git-town.git",
+		configuredHostName: "github.com",
+	}, log)
+	assert.NoError(t, err)
+	assert.NotNil(t, driver)
+	assert.Equal(t, "GitHub", driver.HostingServiceName())
+}
+
+func TestLoad_unknownHostReturnsHelpfulError(t *testing.T) {
+	_, err := drivers.Load(mockConfig{
+		remoteOriginURL: "git@unknown-host.example.com:git-town/git-town.git",
+	}, log)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `no driver registered for host "unknown-host.example.com"`)
+	assert.Contains(t, err.Error(), "github")
+	assert.Contains(t, err.Error(), "gitea")
+}
+
+func TestLoad_unknownDriverNameReturnsHelpfulError(t *testing.T) {
+	_, err := drivers.Load(mockConfig{
+		codeHostingDriverName: "forgejo",
+		remoteOriginURL:       "git@forge.example.com:git-town/git-town.git",
+	}, log)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `no driver registered for host "forgejo"`)
+}
+
+func TestLoad_noRemoteOrigin(t *testing.T) {
+	driver, err := drivers.Load(mockConfig{}, log)
+	assert.NoError(t, err)
+	assert.Nil(t, driver)
+}
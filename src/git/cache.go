@@ -0,0 +1,86 @@
+package git
+
+// BoolCache caches a boolean value that is expensive to compute,
+// for example by shelling out to "git config".
+type BoolCache struct {
+	initialized bool
+	value       bool
+}
+
+// Initialized returns whether this cache has a value stored in it.
+func (c *BoolCache) Initialized() bool {
+	return c.initialized
+}
+
+// Value returns the currently cached value.
+func (c *BoolCache) Value() bool {
+	return c.value
+}
+
+// Set stores the given value in this cache.
+func (c *BoolCache) Set(value bool) {
+	c.initialized = true
+	c.value = value
+}
+
+// Invalidate empties this cache.
+func (c *BoolCache) Invalidate() {
+	c.initialized = false
+	c.value = false
+}
+
+// StringCache caches a string value that is expensive to compute.
+type StringCache struct {
+	initialized bool
+	value       string
+}
+
+// Initialized returns whether this cache has a value stored in it.
+func (c *StringCache) Initialized() bool {
+	return c.initialized
+}
+
+// Value returns the currently cached value.
+func (c *StringCache) Value() string {
+	return c.value
+}
+
+// Set stores the given value in this cache.
+func (c *StringCache) Set(value string) {
+	c.initialized = true
+	c.value = value
+}
+
+// Invalidate empties this cache.
+func (c *StringCache) Invalidate() {
+	c.initialized = false
+	c.value = ""
+}
+
+// StringSliceCache caches a string slice value that is expensive to compute.
+type StringSliceCache struct {
+	initialized bool
+	value       []string
+}
+
+// Initialized returns whether this cache has a value stored in it.
+func (c *StringSliceCache) Initialized() bool {
+	return c.initialized
+}
+
+// Value returns the currently cached value.
+func (c *StringSliceCache) Value() []string {
+	return c.value
+}
+
+// Set stores the given value in this cache.
+func (c *StringSliceCache) Set(value []string) {
+	c.initialized = true
+	c.value = value
+}
+
+// Invalidate empties this cache.
+func (c *StringSliceCache) Invalidate() {
+	c.initialized = false
+	c.value = nil
+}
@@ -0,0 +1,51 @@
+package git
+
+import "path/filepath"
+
+// CachedLocalBranches returns the result of listBranches, persisted under a
+// disk cache keyed by DefaultInvalidators plus changes to loose refs under
+// ".git/refs/remotes", so that the branch list doesn't get recomputed on
+// every "git-town" subcommand invocation.
+func CachedLocalBranches(gitDir string, listBranches func() ([]string, error)) ([]string, error) {
+	invalidators := append(DefaultInvalidators(gitDir), NewRefsInvalidator(filepath.Join(gitDir, "refs", "remotes")))
+	cache := NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+	if cache.Initialized() {
+		return cache.Value(), nil
+	}
+	branches, err := listBranches()
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(branches)
+	return branches, nil
+}
+
+// CachedParentBranch returns the result of lookupParent for the given
+// branch, persisted under a disk cache keyed by DefaultInvalidators.
+func CachedParentBranch(gitDir, branch string, lookupParent func() (string, error)) (string, error) {
+	cache := NewPersistentStringCache(gitDir, "parent-branch."+branch, DefaultInvalidators(gitDir)...)
+	if cache.Initialized() {
+		return cache.Value(), nil
+	}
+	parent, err := lookupParent()
+	if err != nil {
+		return "", err
+	}
+	cache.Set(parent)
+	return parent, nil
+}
+
+// CachedRemotes returns the result of listRemotes, persisted under a disk
+// cache keyed by DefaultInvalidators.
+func CachedRemotes(gitDir string, listRemotes func() ([]string, error)) ([]string, error) {
+	cache := NewPersistentStringSliceCache(gitDir, "remotes", DefaultInvalidators(gitDir)...)
+	if cache.Initialized() {
+		return cache.Value(), nil
+	}
+	remotes, err := listRemotes()
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(remotes)
+	return remotes, nil
+}
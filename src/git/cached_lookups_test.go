@@ -0,0 +1,62 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/src/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedLocalBranches_cachesResult(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	calls := 0
+	listBranches := func() ([]string, error) {
+		calls++
+		return []string{"main", "feature"}, nil
+	}
+
+	branches, err := git.CachedLocalBranches(gitDir, listBranches)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main", "feature"}, branches)
+
+	branches, err = git.CachedLocalBranches(gitDir, listBranches)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main", "feature"}, branches)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCachedParentBranch_cachesResultPerBranch(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	calls := 0
+	lookupParent := func() (string, error) {
+		calls++
+		return "main", nil
+	}
+
+	parent, err := git.CachedParentBranch(gitDir, "feature", lookupParent)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", parent)
+
+	parent, err = git.CachedParentBranch(gitDir, "feature", lookupParent)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", parent)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCachedRemotes_cachesResult(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	calls := 0
+	listRemotes := func() ([]string, error) {
+		calls++
+		return []string{"origin"}, nil
+	}
+
+	remotes, err := git.CachedRemotes(gitDir, listRemotes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"origin"}, remotes)
+
+	remotes, err = git.CachedRemotes(gitDir, listRemotes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"origin"}, remotes)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,259 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cacheSchemaVersion is bumped whenever the on-disk cache file format
+// changes, invalidating every persistent cache entry written by older
+// versions of Git Town.
+const cacheSchemaVersion = 1
+
+// cacheDirName is the directory (relative to ".git") that persistent
+// caches are stored under.
+const cacheDirName = "town-cache"
+
+// Invalidator determines whether a persistent cache entry is still valid by
+// comparing a fingerprint computed now against the one stored alongside the
+// cached value. A cache is invalid as soon as any one of its invalidators
+// reports a different fingerprint than the one it was saved with.
+type Invalidator interface {
+	Fingerprint() string
+}
+
+// fileInvalidator invalidates a cache entry when the given file's
+// modification time changes. It is used for ".git/config" and
+// ".git/packed-refs", which change rarely but affect the results of
+// several expensive git subcommands.
+type fileInvalidator struct {
+	path string
+}
+
+// NewFileInvalidator creates an Invalidator that fires when the file at the
+// given path is modified.
+func NewFileInvalidator(path string) Invalidator {
+	return fileInvalidator{path: path}
+}
+
+func (i fileInvalidator) Fingerprint() string {
+	info, err := os.Stat(i.path)
+	if err != nil {
+		return ""
+	}
+	return info.ModTime().String()
+}
+
+// headInvalidator invalidates a cache entry when ".git/HEAD" itself changes,
+// i.e. when HEAD starts pointing at a different branch (checkouts) or moves
+// into or out of detached-HEAD state. HEAD stays untouched by a commit,
+// merge, or rebase that updates the currently checked-out branch in place;
+// those are caught by refsInvalidator instead.
+type headInvalidator struct {
+	gitDir string
+}
+
+// NewHeadInvalidator creates an Invalidator that fires when the content of
+// ".git/HEAD" changes, e.g. when a different branch gets checked out.
+func NewHeadInvalidator(gitDir string) Invalidator {
+	return headInvalidator{gitDir: gitDir}
+}
+
+func (i headInvalidator) Fingerprint() string {
+	content, err := ioutil.ReadFile(filepath.Join(i.gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	return hashBytes(content)
+}
+
+// refsInvalidator invalidates a cache entry when a loose ref under the
+// given directory (typically ".git/refs/heads") is added, removed, or
+// updated. Git only folds loose refs into ".git/packed-refs" occasionally,
+// so a plain "git branch" or branch deletion would otherwise go unnoticed
+// by fileInvalidator alone.
+type refsInvalidator struct {
+	dir string
+}
+
+// NewRefsInvalidator creates an Invalidator that fires when any loose ref
+// under the given directory changes.
+func NewRefsInvalidator(dir string) Invalidator {
+	return refsInvalidator{dir: dir}
+}
+
+func (i refsInvalidator) Fingerprint() string {
+	var fingerprint strings.Builder
+	_ = filepath.Walk(i.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		fingerprint.WriteString(path)
+		fingerprint.WriteString(info.ModTime().String())
+		return nil
+	})
+	return fingerprint.String()
+}
+
+func hashBytes(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func combinedFingerprint(invalidators []Invalidator) string {
+	hash := sha256.New()
+	for _, invalidator := range invalidators {
+		hash.Write([]byte(invalidator.Fingerprint()))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type persistentCacheEntry struct {
+	SchemaVersion int      `json:"schemaVersion"`
+	Fingerprint   string   `json:"fingerprint"`
+	Bool          bool     `json:"bool,omitempty"`
+	String        string   `json:"string,omitempty"`
+	StringSlice   []string `json:"stringSlice,omitempty"`
+}
+
+func persistentCacheFilePath(gitDir, name string) string {
+	return filepath.Join(gitDir, cacheDirName, name+".json")
+}
+
+// ClearPersistentCaches deletes the entire on-disk cache directory,
+// forcing every persistent cache to recompute from scratch. This backs
+// the "git-town cache clear" subcommand.
+func ClearPersistentCaches(gitDir string) error {
+	return os.RemoveAll(filepath.Join(gitDir, cacheDirName))
+}
+
+func readPersistentCacheEntry(gitDir, name string, invalidators []Invalidator) (persistentCacheEntry, bool) {
+	var entry persistentCacheEntry
+	content, err := ioutil.ReadFile(persistentCacheFilePath(gitDir, name))
+	if err != nil {
+		return entry, false
+	}
+	if err = json.Unmarshal(content, &entry); err != nil {
+		return entry, false
+	}
+	if entry.SchemaVersion != cacheSchemaVersion {
+		return entry, false
+	}
+	if entry.Fingerprint != combinedFingerprint(invalidators) {
+		return entry, false
+	}
+	return entry, true
+}
+
+func writePersistentCacheEntry(gitDir string, name string, invalidators []Invalidator, entry persistentCacheEntry) error {
+	entry.SchemaVersion = cacheSchemaVersion
+	entry.Fingerprint = combinedFingerprint(invalidators)
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	cacheDir := filepath.Join(gitDir, cacheDirName)
+	if err = os.MkdirAll(cacheDir, 0o755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(persistentCacheFilePath(gitDir, name), content, 0o644)
+}
+
+// PersistentBoolCache is a BoolCache that also persists its value to disk,
+// so that it survives across "git-town" subcommand invocations. It falls
+// back to behaving like a plain, process-lifetime BoolCache when the value
+// can't be loaded from or saved to disk.
+type PersistentBoolCache struct {
+	BoolCache
+	gitDir       string
+	name         string
+	invalidators []Invalidator
+}
+
+// NewPersistentBoolCache creates a PersistentBoolCache backed by a file
+// under "<gitDir>/town-cache/<name>.json", invalidated whenever any of the
+// given invalidators reports a changed fingerprint.
+func NewPersistentBoolCache(gitDir, name string, invalidators ...Invalidator) *PersistentBoolCache {
+	cache := &PersistentBoolCache{gitDir: gitDir, name: name, invalidators: invalidators}
+	if entry, ok := readPersistentCacheEntry(gitDir, name, invalidators); ok {
+		cache.BoolCache.Set(entry.Bool)
+	}
+	return cache
+}
+
+// Set stores the given value both in memory and on disk.
+func (c *PersistentBoolCache) Set(value bool) {
+	c.BoolCache.Set(value)
+	_ = writePersistentCacheEntry(c.gitDir, c.name, c.invalidators, persistentCacheEntry{Bool: value})
+}
+
+// PersistentStringCache is a StringCache that also persists its value to disk.
+type PersistentStringCache struct {
+	StringCache
+	gitDir       string
+	name         string
+	invalidators []Invalidator
+}
+
+// NewPersistentStringCache creates a PersistentStringCache backed by a file
+// under "<gitDir>/town-cache/<name>.json", invalidated whenever any of the
+// given invalidators reports a changed fingerprint.
+func NewPersistentStringCache(gitDir, name string, invalidators ...Invalidator) *PersistentStringCache {
+	cache := &PersistentStringCache{gitDir: gitDir, name: name, invalidators: invalidators}
+	if entry, ok := readPersistentCacheEntry(gitDir, name, invalidators); ok {
+		cache.StringCache.Set(entry.String)
+	}
+	return cache
+}
+
+// Set stores the given value both in memory and on disk.
+func (c *PersistentStringCache) Set(value string) {
+	c.StringCache.Set(value)
+	_ = writePersistentCacheEntry(c.gitDir, c.name, c.invalidators, persistentCacheEntry{String: value})
+}
+
+// PersistentStringSliceCache is a StringSliceCache that also persists its
+// value to disk.
+type PersistentStringSliceCache struct {
+	StringSliceCache
+	gitDir       string
+	name         string
+	invalidators []Invalidator
+}
+
+// NewPersistentStringSliceCache creates a PersistentStringSliceCache backed
+// by a file under "<gitDir>/town-cache/<name>.json", invalidated whenever
+// any of the given invalidators reports a changed fingerprint.
+func NewPersistentStringSliceCache(gitDir, name string, invalidators ...Invalidator) *PersistentStringSliceCache {
+	cache := &PersistentStringSliceCache{gitDir: gitDir, name: name, invalidators: invalidators}
+	if entry, ok := readPersistentCacheEntry(gitDir, name, invalidators); ok {
+		cache.StringSliceCache.Set(entry.StringSlice)
+	}
+	return cache
+}
+
+// Set stores the given value both in memory and on disk.
+func (c *PersistentStringSliceCache) Set(value []string) {
+	c.StringSliceCache.Set(value)
+	_ = writePersistentCacheEntry(c.gitDir, c.name, c.invalidators, persistentCacheEntry{StringSlice: value})
+}
+
+// DefaultInvalidators returns the standard set of invalidators used by the
+// persistent caches for branch listings, parent-branch lookups, and remote
+// enumeration: they all become stale when HEAD moves, ".git/config"
+// changes, ".git/packed-refs" changes, or a loose ref under
+// ".git/refs/heads" is added, removed, or updated.
+func DefaultInvalidators(gitDir string) []Invalidator {
+	return []Invalidator{
+		NewHeadInvalidator(gitDir),
+		NewFileInvalidator(filepath.Join(gitDir, "config")),
+		NewFileInvalidator(filepath.Join(gitDir, "packed-refs")),
+		NewRefsInvalidator(filepath.Join(gitDir, "refs", "heads")),
+	}
+}
@@ -0,0 +1,47 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/src/git"
+)
+
+// BenchmarkPersistentStringSliceCache_cold measures a cache miss: every
+// iteration starts without a cache on disk, finds no stored branch list, and
+// stores a freshly supplied one. The cost of computing the branch list itself
+// is not included.
+func BenchmarkPersistentStringSliceCache_cold(b *testing.B) {
+	gitDir := newBenchGitDir(b)
+	invalidators := git.DefaultInvalidators(gitDir)
+	branches := manyBranches(500)
+	for i := 0; i < b.N; i++ {
+		_ = git.ClearPersistentCaches(gitDir)
+		cache := git.NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+		cache.Set(branches)
+	}
+}
+
+// BenchmarkPersistentStringSliceCache_warm simulates a repeat invocation
+// that can serve the branch list straight from the on-disk cache.
+func BenchmarkPersistentStringSliceCache_warm(b *testing.B) {
+	gitDir := newBenchGitDir(b)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+	cache.Set(manyBranches(500))
+	for i := 0; i < b.N; i++ {
+		git.NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+	}
+}
+
+func manyBranches(count int) []string {
+	branches := make([]string, count)
+	for i := range branches {
+		branches[i] = "feature-branch"
+	}
+	return branches
+}
+
+func newBenchGitDir(b *testing.B) string {
+	b.Helper()
+	return newTestGitDir(b)
+}
@@ -0,0 +1,98 @@
+package git_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/git-town/git-town/src/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGitDir(t testing.TB) string {
+	gitDir, err := ioutil.TempDir("", "git-town-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(gitDir, "config"), []byte("[core]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(gitDir) })
+	return gitDir
+}
+
+func TestPersistentBoolCache_survivesReload(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	assert.False(t, cache.Initialized())
+	cache.Set(true)
+
+	reloaded := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	assert.True(t, reloaded.Initialized())
+	assert.True(t, reloaded.Value())
+}
+
+func TestPersistentStringCache_survivesReload(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentStringCache(gitDir, "parent-branch", invalidators...)
+	cache.Set("main")
+
+	reloaded := git.NewPersistentStringCache(gitDir, "parent-branch", invalidators...)
+	assert.True(t, reloaded.Initialized())
+	assert.Equal(t, "main", reloaded.Value())
+}
+
+func TestPersistentStringSliceCache_survivesReload(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+	cache.Set([]string{"main", "feature"})
+
+	reloaded := git.NewPersistentStringSliceCache(gitDir, "local-branches", invalidators...)
+	assert.True(t, reloaded.Initialized())
+	assert.Equal(t, []string{"main", "feature"}, reloaded.Value())
+}
+
+func TestPersistentBoolCache_invalidatesOnHeadChange(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	cache.Set(true)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/feature\n"), 0o644))
+
+	reloaded := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	assert.False(t, reloaded.Initialized())
+}
+
+func TestPersistentBoolCache_invalidatesOnLooseRefChange(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	cache.Set(true)
+
+	refsDir := filepath.Join(gitDir, "refs", "heads")
+	assert.NoError(t, os.MkdirAll(refsDir, 0o755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(refsDir, "feature"), []byte("abc123\n"), 0o644))
+
+	reloaded := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	assert.False(t, reloaded.Initialized())
+}
+
+func TestClearPersistentCaches(t *testing.T) {
+	gitDir := newTestGitDir(t)
+	invalidators := git.DefaultInvalidators(gitDir)
+	cache := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	cache.Set(true)
+
+	assert.NoError(t, git.ClearPersistentCaches(gitDir))
+
+	reloaded := git.NewPersistentBoolCache(gitDir, "has-origin", invalidators...)
+	assert.False(t, reloaded.Initialized())
+}